@@ -0,0 +1,168 @@
+package coze
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// AudioFrame is a block of decoded PCM samples produced by an
+// AudioFrameReader.
+type AudioFrame struct {
+	PCM        []int16
+	SampleRate int
+	Channels   int
+}
+
+// AudioFrameReader reads successive decoded AudioFrame values from an audio
+// stream, returning io.EOF once exhausted.
+type AudioFrameReader interface {
+	ReadFrame() (*AudioFrame, error)
+}
+
+// AudioCodec describes an audio container/codec that can be used as the
+// source format for transcription uploads or the target format for speech
+// synthesis.
+type AudioCodec interface {
+	// MIMEType is the Content-Type used when uploading audio in this codec.
+	MIMEType() string
+
+	// Extension is the file extension used for the multipart form filename.
+	Extension() string
+
+	// Decoder optionally returns a reader that exposes decoded PCM frames.
+	// Codecs that only pass audio through opaquely (e.g. most compressed
+	// formats without a pure-Go decoder available) may return
+	// ErrAudioCodecNoDecoder.
+	Decoder(r io.Reader) (AudioFrameReader, error)
+}
+
+// ErrAudioCodecNoDecoder is returned by AudioCodec.Decoder for codecs that
+// don't support decoding to PCM frames in this package.
+var ErrAudioCodecNoDecoder = fmt.Errorf("coze: codec does not support decoding")
+
+var (
+	audioCodecMu       sync.RWMutex
+	audioCodecRegistry = map[string]AudioCodec{
+		"mp3":       mp3Codec{},
+		"wav":       wavCodec{},
+		"ogg_opus":  oggOpusCodec{},
+		"flac":      flacCodec{},
+		"pcm_s16le": pcmS16LECodec{},
+	}
+)
+
+// RegisterAudioCodec registers c under name, overriding any existing codec
+// registered with that name (including the built-ins). It is safe for
+// concurrent use.
+func RegisterAudioCodec(name string, c AudioCodec) {
+	audioCodecMu.Lock()
+	defer audioCodecMu.Unlock()
+	audioCodecRegistry[name] = c
+}
+
+type mp3Codec struct{}
+
+func (mp3Codec) MIMEType() string  { return "audio/mpeg" }
+func (mp3Codec) Extension() string { return "mp3" }
+func (mp3Codec) Decoder(io.Reader) (AudioFrameReader, error) {
+	return nil, ErrAudioCodecNoDecoder
+}
+
+type oggOpusCodec struct{}
+
+func (oggOpusCodec) MIMEType() string  { return "audio/ogg" }
+func (oggOpusCodec) Extension() string { return "ogg" }
+func (oggOpusCodec) Decoder(io.Reader) (AudioFrameReader, error) {
+	return nil, ErrAudioCodecNoDecoder
+}
+
+type flacCodec struct{}
+
+func (flacCodec) MIMEType() string  { return "audio/flac" }
+func (flacCodec) Extension() string { return "flac" }
+func (flacCodec) Decoder(io.Reader) (AudioFrameReader, error) {
+	return nil, ErrAudioCodecNoDecoder
+}
+
+type wavCodec struct{}
+
+func (wavCodec) MIMEType() string  { return "audio/wav" }
+func (wavCodec) Extension() string { return "wav" }
+
+// Decoder parses a canonical PCM WAV file and exposes it as a single
+// AudioFrame; it does not support non-PCM WAV formats.
+func (wavCodec) Decoder(r io.Reader) (AudioFrameReader, error) {
+	var header struct {
+		RIFF          [4]byte
+		ChunkSize     uint32
+		WAVE          [4]byte
+		FmtID         [4]byte
+		FmtSize       uint32
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		DataID        [4]byte
+		DataSize      uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("coze: read wav header: %w", err)
+	}
+	if string(header.RIFF[:]) != "RIFF" || string(header.WAVE[:]) != "WAVE" {
+		return nil, fmt.Errorf("coze: not a RIFF/WAVE file")
+	}
+	if header.AudioFormat != 1 || header.BitsPerSample != 16 {
+		return nil, fmt.Errorf("coze: only 16-bit PCM WAV is supported")
+	}
+
+	pcm := make([]int16, header.DataSize/2)
+	if err := binary.Read(r, binary.LittleEndian, &pcm); err != nil {
+		return nil, fmt.Errorf("coze: read wav data: %w", err)
+	}
+
+	return &singleFrameReader{frame: &AudioFrame{
+		PCM:        pcm,
+		SampleRate: int(header.SampleRate),
+		Channels:   int(header.Channels),
+	}}, nil
+}
+
+type pcmS16LECodec struct{}
+
+func (pcmS16LECodec) MIMEType() string  { return "audio/L16" }
+func (pcmS16LECodec) Extension() string { return "pcm" }
+
+// Decoder treats r as a headerless stream of little-endian 16-bit mono
+// samples; callers that need the real sample rate/channel count should
+// track it out of band.
+func (pcmS16LECodec) Decoder(r io.Reader) (AudioFrameReader, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("coze: read pcm_s16le data: %w", err)
+	}
+	pcm := make([]int16, len(raw)/2)
+	if err := binary.Read(bytes.NewReader(raw), binary.LittleEndian, &pcm); err != nil {
+		return nil, fmt.Errorf("coze: decode pcm_s16le data: %w", err)
+	}
+	return &singleFrameReader{frame: &AudioFrame{PCM: pcm, Channels: 1}}, nil
+}
+
+// singleFrameReader adapts a single pre-decoded AudioFrame to the
+// AudioFrameReader interface.
+type singleFrameReader struct {
+	frame *AudioFrame
+	read  bool
+}
+
+func (s *singleFrameReader) ReadFrame() (*AudioFrame, error) {
+	if s.read {
+		return nil, io.EOF
+	}
+	s.read = true
+	return s.frame, nil
+}
@@ -0,0 +1,83 @@
+package coze
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAudioCodec(t *testing.T) {
+	t.Run("built-ins are registered", func(t *testing.T) {
+		for _, name := range []string{"mp3", "wav", "ogg_opus", "flac", "pcm_s16le"} {
+			codec, err := lookupAudioCodec(name)
+			require.NoError(t, err)
+			assert.NotEmpty(t, codec.MIMEType())
+			assert.NotEmpty(t, codec.Extension())
+		}
+	})
+
+	t.Run("unknown codec errors", func(t *testing.T) {
+		_, err := lookupAudioCodec("does-not-exist")
+		require.Error(t, err)
+	})
+
+	t.Run("custom codec overrides", func(t *testing.T) {
+		RegisterAudioCodec("mp3", fakeCodec{mime: "audio/custom-mp3"})
+		defer RegisterAudioCodec("mp3", mp3Codec{})
+
+		codec, err := lookupAudioCodec("mp3")
+		require.NoError(t, err)
+		assert.Equal(t, "audio/custom-mp3", codec.MIMEType())
+	})
+}
+
+func TestWAVCodecDecoder(t *testing.T) {
+	samples := []int16{100, -100, 200, -200}
+	data := &bytes.Buffer{}
+	require.NoError(t, binary.Write(data, binary.LittleEndian, samples))
+
+	header := &bytes.Buffer{}
+	header.WriteString("RIFF")
+	_ = binary.Write(header, binary.LittleEndian, uint32(36+data.Len()))
+	header.WriteString("WAVE")
+	header.WriteString("fmt ")
+	_ = binary.Write(header, binary.LittleEndian, uint32(16))
+	_ = binary.Write(header, binary.LittleEndian, uint16(1)) // PCM
+	_ = binary.Write(header, binary.LittleEndian, uint16(1)) // mono
+	_ = binary.Write(header, binary.LittleEndian, uint32(16000))
+	_ = binary.Write(header, binary.LittleEndian, uint32(32000))
+	_ = binary.Write(header, binary.LittleEndian, uint16(2))
+	_ = binary.Write(header, binary.LittleEndian, uint16(16))
+	header.WriteString("data")
+	_ = binary.Write(header, binary.LittleEndian, uint32(data.Len()))
+	header.Write(data.Bytes())
+
+	codec, err := lookupAudioCodec("wav")
+	require.NoError(t, err)
+
+	reader, err := codec.Decoder(bytes.NewReader(header.Bytes()))
+	require.NoError(t, err)
+
+	frame, err := reader.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, samples, frame.PCM)
+	assert.Equal(t, 16000, frame.SampleRate)
+	assert.Equal(t, 1, frame.Channels)
+
+	_, err = reader.ReadFrame()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+type fakeCodec struct {
+	mime string
+}
+
+func (c fakeCodec) MIMEType() string { return c.mime }
+func (fakeCodec) Extension() string  { return "mp3" }
+func (fakeCodec) Decoder(io.Reader) (AudioFrameReader, error) {
+	return nil, ErrAudioCodecNoDecoder
+}
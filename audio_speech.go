@@ -0,0 +1,94 @@
+package coze
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// AudioFormat is the audio container/codec accepted by CreateAudioSpeechReq
+// and advertised via the codec registry in audio_codec.go.
+type AudioFormat string
+
+const (
+	AudioFormatMP3      AudioFormat = "mp3"
+	AudioFormatWAV      AudioFormat = "wav"
+	AudioFormatOggOpus  AudioFormat = "ogg_opus"
+	AudioFormatFLAC     AudioFormat = "flac"
+	AudioFormatPCMS16LE AudioFormat = "pcm_s16le"
+)
+
+// Ptr returns a pointer to the AudioFormat value, for optional request fields.
+func (f AudioFormat) Ptr() *AudioFormat {
+	return &f
+}
+
+// CreateAudioSpeechReq is the request to synthesize speech from text.
+type CreateAudioSpeechReq struct {
+	Input          string       `json:"input"`
+	VoiceID        string       `json:"voice_id"`
+	ResponseFormat *AudioFormat `json:"response_format,omitempty"`
+	Speed          *float32     `json:"speed,omitempty"`
+
+	// SampleRate requests a specific output sample rate in Hz; zero leaves it
+	// up to the server/codec default.
+	SampleRate int `json:"sample_rate,omitempty"`
+
+	// Channels requests mono (1) or stereo (2) output; zero leaves it up to
+	// the server/codec default.
+	Channels int `json:"channels,omitempty"`
+
+	// Codec names a codec registered via RegisterAudioCodec. When set, the
+	// codec's MIME type is sent as the request's Accept header; ResponseFormat
+	// is still sent in the request body unchanged.
+	Codec string `json:"-"`
+}
+
+// CreateAudioSpeechResp is the response to CreateAudioSpeechReq.
+type CreateAudioSpeechResp struct {
+	HTTPResponse *HTTPResponse
+
+	// Data is the raw encoded audio body; callers must close it.
+	Data io.ReadCloser
+}
+
+type speech struct {
+	core *core
+}
+
+func newSpeech(core *core) *speech {
+	return &speech{core: core}
+}
+
+// Create synthesizes speech from text and returns the raw audio body.
+func (r *speech) Create(ctx context.Context, req *CreateAudioSpeechReq) (*CreateAudioSpeechResp, error) {
+	header := http.Header{}
+	if req.Codec != "" {
+		codec, err := lookupAudioCodec(req.Codec)
+		if err != nil {
+			return nil, err
+		}
+		header.Set("Accept", codec.MIMEType())
+	}
+
+	httpResp, err := r.core.rawRequestWithHeader(ctx, http.MethodPost, "/v1/audio/speech", header, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAudioSpeechResp{
+		HTTPResponse: httpResp.HTTPResponse,
+		Data:         httpResp.Body,
+	}, nil
+}
+
+func lookupAudioCodec(name string) (AudioCodec, error) {
+	audioCodecMu.RLock()
+	defer audioCodecMu.RUnlock()
+	codec, ok := audioCodecRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("coze: unknown audio codec %q", name)
+	}
+	return codec, nil
+}
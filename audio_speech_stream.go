@@ -0,0 +1,242 @@
+package coze
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// audioSpeechStreamPath is the WebSocket endpoint used for streaming TTS.
+// It mirrors the REST path used by speech.Create but is dialed over wss://
+// (or ws:// when the base URL is not https).
+const audioSpeechStreamPath = "/v1/audio/speech"
+
+// CreateAudioSpeechStreamReq is the request to open a streaming TTS session.
+type CreateAudioSpeechStreamReq struct {
+	// VoiceID is the voice to synthesize with.
+	VoiceID string `json:"voice_id"`
+
+	// ResponseFormat is the audio container/codec the server should emit.
+	ResponseFormat *AudioFormat `json:"response_format,omitempty"`
+
+	// Speed controls the playback rate, in the same range as CreateAudioSpeechReq.Speed.
+	Speed *float32 `json:"speed,omitempty"`
+
+	// Path overrides the default WebSocket path, mainly for testing.
+	Path string `json:"-"`
+}
+
+// AudioSpeechStreamEventType identifies the kind of event emitted by Recv.
+type AudioSpeechStreamEventType string
+
+const (
+	AudioSpeechStreamEventStart AudioSpeechStreamEventType = "start"
+	AudioSpeechStreamEventChunk AudioSpeechStreamEventType = "chunk"
+	AudioSpeechStreamEventEnd   AudioSpeechStreamEventType = "end"
+	AudioSpeechStreamEventError AudioSpeechStreamEventType = "error"
+)
+
+// AudioSpeechStreamEvent is the typed union returned by AudioSpeechStream.Recv.
+type AudioSpeechStreamEvent struct {
+	Type AudioSpeechStreamEventType
+
+	// Chunk holds decoded PCM/encoded audio bytes when Type == AudioSpeechStreamEventChunk.
+	Chunk []byte
+
+	// Err holds the failure reason when Type == AudioSpeechStreamEventError.
+	Err error
+}
+
+// AudioSpeechStream is a bidirectional streaming TTS session: callers push text
+// via SendText and read synthesized audio back via Recv.
+type AudioSpeechStream struct {
+	core *core
+
+	ctx   context.Context
+	conn  *websocket.Conn
+	logID string
+
+	// done is closed once, by Close, to stop the context-cancellation
+	// watcher goroutine started in CreateStream.
+	done chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	ended  bool
+}
+
+// CreateStream opens a streaming TTS session over WebSocket. Callers push
+// text with SendText, signal completion with Finish, and consume audio and
+// progress events with Recv until it returns io.EOF. Cancelling ctx unblocks
+// a pending Recv and closes the stream.
+func (r *speech) CreateStream(ctx context.Context, req *CreateAudioSpeechStreamReq) (*AudioSpeechStream, error) {
+	path := req.Path
+	if path == "" {
+		path = audioSpeechStreamPath
+	}
+
+	wsURL, err := toWebSocketURL(r.core.baseURL, path)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := r.core.authHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, httpResp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("coze: dial audio speech stream: %w", err)
+	}
+
+	stream := &AudioSpeechStream{
+		core: r.core,
+		ctx:  ctx,
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	if httpResp != nil {
+		stream.logID = httpResp.Header.Get(logIDHeader)
+	}
+
+	if err := stream.sendJSON(map[string]any{
+		"event_type":      "start",
+		"voice_id":        req.VoiceID,
+		"response_format": req.ResponseFormat,
+		"speed":           req.Speed,
+	}); err != nil {
+		stream.Close()
+		return nil, fmt.Errorf("coze: send audio speech stream start: %w", err)
+	}
+
+	go stream.watchContext(ctx)
+
+	return stream, nil
+}
+
+// watchContext unblocks a pending Recv by forcing a read deadline once ctx is
+// canceled, and exits once the stream is closed normally.
+func (s *AudioSpeechStream) watchContext(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		_ = s.conn.SetReadDeadline(time.Now())
+		s.mu.Unlock()
+	case <-s.done:
+	}
+}
+
+// LogID returns the log ID of the WebSocket upgrade response, matching
+// TranscriptionStream.LogID() for the streaming ASR API.
+func (s *AudioSpeechStream) LogID() string {
+	return s.logID
+}
+
+// SendText pushes an incremental chunk of text to be synthesized.
+func (s *AudioSpeechStream) SendText(text string) error {
+	return s.sendJSON(map[string]any{
+		"event_type": "text",
+		"text":       text,
+	})
+}
+
+// Finish signals that no further text will be sent and the server should
+// flush and close the stream once synthesis completes.
+func (s *AudioSpeechStream) Finish() error {
+	return s.sendJSON(map[string]any{
+		"event_type": "finish",
+	})
+}
+
+// Recv blocks until the next event is available. Once the end event has been
+// returned, every later call returns io.EOF without reading from the
+// connection. If ctx passed
+// to CreateStream is canceled or its deadline expires, Recv unblocks and
+// returns ctx.Err().
+func (s *AudioSpeechStream) Recv() (*AudioSpeechStreamEvent, error) {
+	s.mu.Lock()
+	ended := s.ended
+	s.mu.Unlock()
+	if ended {
+		return nil, io.EOF
+	}
+
+	msgType, data, err := s.conn.ReadMessage()
+	if err != nil {
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if closeErr, ok := err.(*websocket.CloseError); ok {
+			return nil, fmt.Errorf("coze: audio speech stream closed (code %d): %s", closeErr.Code, closeErr.Text)
+		}
+		return nil, err
+	}
+
+	if msgType == websocket.BinaryMessage {
+		return &AudioSpeechStreamEvent{Type: AudioSpeechStreamEventChunk, Chunk: data}, nil
+	}
+
+	event, err := parseAudioSpeechStreamEvent(data)
+	if err != nil {
+		return nil, err
+	}
+	if event.Type == AudioSpeechStreamEventEnd {
+		s.mu.Lock()
+		s.ended = true
+		s.mu.Unlock()
+	}
+	return event, nil
+}
+
+// Close closes the underlying WebSocket connection and stops the
+// ctx-cancellation watcher started by CreateStream.
+func (s *AudioSpeechStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	return s.conn.Close()
+}
+
+func (s *AudioSpeechStream) sendJSON(v map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WriteJSON(v)
+}
+
+func toWebSocketURL(baseURL, path string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("coze: parse base url: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = path
+	return u.String(), nil
+}
+
+func parseAudioSpeechStreamEvent(data []byte) (*AudioSpeechStreamEvent, error) {
+	eventType := AudioSpeechStreamEventType(strings.TrimSpace(string(data)))
+	switch eventType {
+	case AudioSpeechStreamEventStart, AudioSpeechStreamEventEnd:
+		return &AudioSpeechStreamEvent{Type: eventType}, nil
+	case AudioSpeechStreamEventError:
+		return &AudioSpeechStreamEvent{Type: eventType, Err: fmt.Errorf("coze: audio speech stream reported an error")}, nil
+	default:
+		return &AudioSpeechStreamEvent{Type: AudioSpeechStreamEventChunk, Chunk: data}, nil
+	}
+}
@@ -0,0 +1,110 @@
+package coze
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockSpeechStreamServer spins up a test WebSocket server that sends a
+// start event right after the upgrade and a fixed chunk/end sequence once it
+// sees a "finish" control message, playing the role mockTransport plays for
+// the plain HTTP speech tests.
+func newMockSpeechStreamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/v1/audio/speech", req.URL.Path)
+
+		responseHeader := http.Header{}
+		responseHeader.Set(logIDHeader, "test_log_id")
+		conn, err := upgrader.Upgrade(w, req, responseHeader)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(AudioSpeechStreamEventStart)))
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if strings.Contains(string(msg), `"finish"`) {
+				require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte("mock audio chunk")))
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(AudioSpeechStreamEventEnd)))
+				return
+			}
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAudioSpeechStream(t *testing.T) {
+	t.Run("stream start, chunk, end", func(t *testing.T) {
+		srv := newMockSpeechStreamServer(t)
+
+		core := newCore(http.DefaultClient, srv.URL)
+		speech := newSpeech(core)
+
+		stream, err := speech.CreateStream(context.Background(), &CreateAudioSpeechStreamReq{
+			VoiceID:        "voice1",
+			ResponseFormat: AudioFormatMP3.Ptr(),
+		})
+		require.NoError(t, err)
+		defer stream.Close()
+		assert.NotEmpty(t, stream.LogID())
+
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, AudioSpeechStreamEventStart, ev.Type)
+
+		require.NoError(t, stream.SendText("Hello, world!"))
+		require.NoError(t, stream.Finish())
+
+		ev, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, AudioSpeechStreamEventChunk, ev.Type)
+		require.Equal(t, "mock audio chunk", string(ev.Chunk))
+
+		ev, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, AudioSpeechStreamEventEnd, ev.Type)
+
+		// Once the end event has been delivered, Recv reports io.EOF without
+		// blocking on the now-closed server-side connection.
+		_, err = stream.Recv()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("cancellation alone unblocks Recv", func(t *testing.T) {
+		srv := newMockSpeechStreamServer(t)
+
+		core := newCore(http.DefaultClient, srv.URL)
+		speech := newSpeech(core)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := speech.CreateStream(ctx, &CreateAudioSpeechStreamReq{VoiceID: "voice1"})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		// Drain the start event so the next Recv actually blocks on the
+		// connection, rather than returning a buffered message.
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		cancel()
+
+		_, err = stream.Recv()
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
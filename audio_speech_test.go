@@ -8,31 +8,26 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hachi-zzq/coze-go/internal/cozetest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAudioSpeech(t *testing.T) {
-	// Test Create method
+	// Test Create method, migrated to the cozetest ad-hoc server harness as
+	// a demonstration; the remaining subtests below still use mockTransport.
 	t.Run("Create speech success", func(t *testing.T) {
-		mockTransport := &mockTransport{
-			roundTripFunc: func(req *http.Request) (*http.Response, error) {
-				// Verify request method and path
-				assert.Equal(t, http.MethodPost, req.Method)
-				assert.Equal(t, "/v1/audio/speech", req.URL.Path)
-
-				// Return mock response with audio data
-				resp := &http.Response{
-					StatusCode: http.StatusOK,
-					Header:     http.Header{},
-					Body:       io.NopCloser(strings.NewReader("mock audio data")),
-				}
-				resp.Header.Set(logIDHeader, "test_log_id")
-				return resp, nil
-			},
-		}
+		client, srv, teardown := cozetest.NewServer(t)
+		defer teardown()
+
+		srv.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			w.Header().Set(logIDHeader, "test_log_id")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("mock audio data"))
+		})
 
-		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		core := newCore(client, srv.URL())
 		speech := newSpeech(core)
 
 		resp, err := speech.Create(context.Background(), &CreateAudioSpeechReq{
@@ -0,0 +1,20 @@
+package coze
+
+import (
+	"context"
+	"net/http"
+)
+
+// authHeader builds the Authorization/log-id headers used to dial the
+// streaming WebSocket endpoints, reusing the same credential core already
+// injects into plain HTTP requests.
+func (c *core) authHeader(ctx context.Context) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.auth.setAuthorizationHeader(ctx, req); err != nil {
+		return nil, err
+	}
+	return req.Header, nil
+}
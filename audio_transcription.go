@@ -0,0 +1,320 @@
+package coze
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// TranscriptionResponseFormat selects how the server renders a transcription
+// result.
+type TranscriptionResponseFormat string
+
+const (
+	TranscriptionResponseFormatJSON        TranscriptionResponseFormat = "json"
+	TranscriptionResponseFormatVerboseJSON TranscriptionResponseFormat = "verbose_json"
+	TranscriptionResponseFormatSRT         TranscriptionResponseFormat = "srt"
+	TranscriptionResponseFormatVTT         TranscriptionResponseFormat = "vtt"
+	TranscriptionResponseFormatText        TranscriptionResponseFormat = "text"
+)
+
+// Segment is a single timed caption line parsed out of an srt/vtt response.
+type Segment struct {
+	Index int
+	Start float64
+	End   float64
+	Text  string
+}
+
+// AudioTranscriptionData is the decoded body of a transcription response.
+type AudioTranscriptionData struct {
+	Text string `json:"text"`
+
+	// Segments is populated when ResponseFormat is srt or vtt.
+	Segments []Segment `json:"-"`
+}
+
+// AudioTranscriptionResp is the response to Transcription.
+type AudioTranscriptionResp struct {
+	HTTPResponse *HTTPResponse
+	Data         *AudioTranscriptionData
+}
+
+// transcriptionOptions holds the fields TranscriptionOption funcs populate;
+// it mirrors the multipart form fields the server accepts.
+type transcriptionOptions struct {
+	language       string
+	prompt         string
+	temperature    *float32
+	responseFormat TranscriptionResponseFormat
+	codec          string
+}
+
+// TranscriptionOption configures a call to Transcription.
+type TranscriptionOption func(*transcriptionOptions)
+
+// WithTranscriptionLanguage sets the expected input language, e.g. "en".
+func WithTranscriptionLanguage(language string) TranscriptionOption {
+	return func(o *transcriptionOptions) { o.language = language }
+}
+
+// WithTranscriptionPrompt supplies optional context to steer transcription,
+// e.g. expected vocabulary.
+func WithTranscriptionPrompt(prompt string) TranscriptionOption {
+	return func(o *transcriptionOptions) { o.prompt = prompt }
+}
+
+// WithTranscriptionTemperature sets the sampling temperature used by the
+// underlying model.
+func WithTranscriptionTemperature(temperature float32) TranscriptionOption {
+	return func(o *transcriptionOptions) { o.temperature = &temperature }
+}
+
+// WithTranscriptionResponseFormat selects the response rendering; it
+// defaults to TranscriptionResponseFormatJSON.
+func WithTranscriptionResponseFormat(format TranscriptionResponseFormat) TranscriptionOption {
+	return func(o *transcriptionOptions) { o.responseFormat = format }
+}
+
+// WithTranscriptionCodec names a codec registered via RegisterAudioCodec to
+// derive the multipart Content-Type for the uploaded audio; it defaults to
+// "mp3" for backward compatibility with the previous raw-reader signature.
+func WithTranscriptionCodec(name string) TranscriptionOption {
+	return func(o *transcriptionOptions) { o.codec = name }
+}
+
+// Transcription uploads audio and returns its transcript, assuming mp3 audio
+// and a json response. It is a thin wrapper around TranscriptionWithOptions
+// kept for backward compatibility; new callers should prefer
+// TranscriptionWithOptions.
+func (r *speech) Transcription(ctx context.Context, audio io.Reader, lang string) (*AudioTranscriptionResp, error) {
+	var opts []TranscriptionOption
+	if lang != "" {
+		opts = append(opts, WithTranscriptionLanguage(lang))
+	}
+	return r.TranscriptionWithOptions(ctx, audio, opts...)
+}
+
+// TranscriptionWithOptions uploads audio and returns its transcript. Pass
+// TranscriptionOption values to set the language, prompt, temperature,
+// response format, or source codec; it defaults to mp3 audio with a json
+// response.
+func (r *speech) TranscriptionWithOptions(ctx context.Context, audio io.Reader, opts ...TranscriptionOption) (*AudioTranscriptionResp, error) {
+	options := transcriptionOptions{
+		responseFormat: TranscriptionResponseFormatJSON,
+		codec:          "mp3",
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	codec, err := lookupAudioCodec(options.codec)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {fmt.Sprintf(`form-data; name="file"; filename="audio.%s"`, codec.Extension())},
+		"Content-Type":        {codec.MIMEType()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("coze: create transcription form file: %w", err)
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return nil, fmt.Errorf("coze: copy transcription audio: %w", err)
+	}
+
+	writeFormField(writer, "response_format", string(options.responseFormat))
+	writeFormField(writer, "language", options.language)
+	writeFormField(writer, "prompt", options.prompt)
+	if options.temperature != nil {
+		writeFormField(writer, "temperature", strconv.FormatFloat(float64(*options.temperature), 'f', -1, 32))
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("coze: close transcription form: %w", err)
+	}
+
+	httpResp, err := r.core.rawRequestWithContentType(ctx, http.MethodPost, "/v1/audio/transcriptions", writer.FormDataContentType(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("coze: read transcription response: %w", err)
+	}
+
+	data, err := decodeTranscriptionResponse(options.responseFormat, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AudioTranscriptionResp{HTTPResponse: httpResp.HTTPResponse, Data: data}, nil
+}
+
+func writeFormField(writer *multipart.Writer, name, value string) {
+	if value == "" {
+		return
+	}
+	_ = writer.WriteField(name, value)
+}
+
+func decodeTranscriptionResponse(format TranscriptionResponseFormat, raw []byte) (*AudioTranscriptionData, error) {
+	switch format {
+	case TranscriptionResponseFormatSRT:
+		segments, err := parseSRT(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		return &AudioTranscriptionData{Text: joinSegments(segments), Segments: segments}, nil
+	case TranscriptionResponseFormatVTT:
+		segments, err := parseVTT(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		return &AudioTranscriptionData{Text: joinSegments(segments), Segments: segments}, nil
+	case TranscriptionResponseFormatText:
+		return &AudioTranscriptionData{Text: strings.TrimSpace(string(raw))}, nil
+	default:
+		var wire struct {
+			Data AudioTranscriptionData `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &wire); err != nil {
+			return nil, fmt.Errorf("coze: decode transcription response: %w", err)
+		}
+		return &wire.Data, nil
+	}
+}
+
+func joinSegments(segments []Segment) string {
+	parts := make([]string, len(segments))
+	for i, s := range segments {
+		parts[i] = s.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseSRT parses the minimal subset of SubRip needed to recover text
+// segments with start/end offsets in seconds.
+func parseSRT(raw string) ([]Segment, error) {
+	var segments []Segment
+	for _, block := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) < 3 {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+		if err != nil {
+			continue
+		}
+		start, end, err := parseSRTTiming(lines[1])
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, Segment{
+			Index: index,
+			Start: start,
+			End:   end,
+			Text:  strings.Join(lines[2:], " "),
+		})
+	}
+	return segments, nil
+}
+
+// parseVTT parses the minimal subset of WebVTT needed to recover text
+// segments with start/end offsets in seconds.
+func parseVTT(raw string) ([]Segment, error) {
+	body := strings.TrimPrefix(strings.ReplaceAll(raw, "\r\n", "\n"), "WEBVTT\n")
+	var segments []Segment
+	index := 0
+	for _, block := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) < 2 || !strings.Contains(lines[0], "-->") {
+			continue
+		}
+		start, end, err := parseVTTTiming(lines[0])
+		if err != nil {
+			return nil, err
+		}
+		index++
+		segments = append(segments, Segment{Index: index, Start: start, End: end, Text: strings.Join(lines[1:], " ")})
+	}
+	return segments, nil
+}
+
+// parseSRTTiming parses a "00:00:01,000 --> 00:00:02,500" timing line into
+// start/end seconds.
+func parseSRTTiming(line string) (start, end float64, err error) {
+	fields := strings.SplitN(line, "-->", 2)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("coze: invalid srt timing %q", line)
+	}
+	start, err = parseSRTTimestamp(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err = parseSRTTimestamp(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseSRTTimestamp(ts string) (float64, error) {
+	var h, m, s, ms int
+	if _, err := fmt.Sscanf(ts, "%d:%d:%d,%d", &h, &m, &s, &ms); err != nil {
+		return 0, fmt.Errorf("coze: invalid srt timestamp %q: %w", ts, err)
+	}
+	return float64(h*3600+m*60+s) + float64(ms)/1000, nil
+}
+
+// parseVTTTiming parses a WebVTT "<start> --> <end> [cue settings]" timing
+// line. Unlike SRT, the hours component is optional (e.g. "01:02.500").
+func parseVTTTiming(line string) (start, end float64, err error) {
+	fields := strings.SplitN(line, "-->", 2)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("coze: invalid vtt timing %q", line)
+	}
+
+	start, err = parseVTTTimestamp(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The end timestamp may be followed by cue settings (e.g. "align:start");
+	// only the first field is the timestamp.
+	endFields := strings.Fields(strings.TrimSpace(fields[1]))
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Errorf("coze: invalid vtt timing %q", line)
+	}
+	end, err = parseVTTTimestamp(endFields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseVTTTimestamp parses a WebVTT timestamp in either "HH:MM:SS.mmm" or
+// the hours-optional "MM:SS.mmm" form.
+func parseVTTTimestamp(ts string) (float64, error) {
+	var h, m, s, ms int
+	if n, err := fmt.Sscanf(ts, "%d:%d:%d.%d", &h, &m, &s, &ms); err == nil && n == 4 {
+		return float64(h*3600+m*60+s) + float64(ms)/1000, nil
+	}
+	if n, err := fmt.Sscanf(ts, "%d:%d.%d", &m, &s, &ms); err == nil && n == 3 {
+		return float64(m*60+s) + float64(ms)/1000, nil
+	}
+	return 0, fmt.Errorf("coze: invalid vtt timestamp %q", ts)
+}
@@ -0,0 +1,174 @@
+package coze
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioSpeechCreateCodec(t *testing.T) {
+	t.Run("Codec sets the Accept header", func(t *testing.T) {
+		mockTransport := &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "audio/wav", req.Header.Get("Accept"))
+
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("mock wav data")),
+				}
+				resp.Header.Set(logIDHeader, "test_log_id")
+				return resp, nil
+			},
+		}
+
+		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		speech := newSpeech(core)
+
+		resp, err := speech.Create(context.Background(), &CreateAudioSpeechReq{
+			Input:   "Hello, world!",
+			VoiceID: "voice1",
+			Codec:   "wav",
+		})
+		require.NoError(t, err)
+		defer resp.Data.Close()
+	})
+
+	t.Run("unknown codec errors", func(t *testing.T) {
+		core := newCore(&http.Client{Transport: &mockTransport{}}, ComBaseURL)
+		speech := newSpeech(core)
+
+		_, err := speech.Create(context.Background(), &CreateAudioSpeechReq{
+			Input:   "Hello, world!",
+			VoiceID: "voice1",
+			Codec:   "does-not-exist",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestAudioTranscriptionWithOptions(t *testing.T) {
+	t.Run("multipart request carries codec, language and response format", func(t *testing.T) {
+		mockTransport := &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/v1/audio/transcriptions", req.URL.Path)
+				assert.True(t, strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data"))
+
+				require.NoError(t, req.ParseMultipartForm(1<<20))
+				assert.Equal(t, "en", req.FormValue("language"))
+				assert.Equal(t, string(TranscriptionResponseFormatVerboseJSON), req.FormValue("response_format"))
+
+				fileHeaders := req.MultipartForm.File["file"]
+				require.Len(t, fileHeaders, 1)
+				assert.Equal(t, "audio/wav", fileHeaders[0].Header.Get("Content-Type"))
+
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"text":"hello there"}}`)),
+				}
+				resp.Header.Set(logIDHeader, "test_log_id")
+				return resp, nil
+			},
+		}
+
+		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		speech := newSpeech(core)
+
+		resp, err := speech.TranscriptionWithOptions(context.Background(), strings.NewReader("wav bytes"),
+			WithTranscriptionCodec("wav"),
+			WithTranscriptionLanguage("en"),
+			WithTranscriptionResponseFormat(TranscriptionResponseFormatVerboseJSON),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "test_log_id", resp.HTTPResponse.LogID())
+		assert.Equal(t, "hello there", resp.Data.Text)
+	})
+
+	t.Run("srt response is parsed into segments", func(t *testing.T) {
+		srt := "1\n00:00:00,000 --> 00:00:01,500\nhello\n\n2\n00:00:01,500 --> 00:00:03,000\nworld\n"
+
+		mockTransport := &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(srt)),
+				}
+				resp.Header.Set(logIDHeader, "test_log_id")
+				return resp, nil
+			},
+		}
+
+		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		speech := newSpeech(core)
+
+		resp, err := speech.TranscriptionWithOptions(context.Background(), strings.NewReader("mp3 bytes"),
+			WithTranscriptionResponseFormat(TranscriptionResponseFormatSRT),
+		)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Data.Segments, 2)
+		assert.Equal(t, "hello", resp.Data.Segments[0].Text)
+		assert.Equal(t, 1.5, resp.Data.Segments[0].End)
+		assert.Equal(t, "world", resp.Data.Segments[1].Text)
+	})
+
+	t.Run("vtt response with hours-optional timestamps is parsed into segments", func(t *testing.T) {
+		vtt := "WEBVTT\n\n00:00.000 --> 00:01.500\nhello\n\n00:01.500 --> 01:00:03.000 align:start\nworld\n"
+
+		mockTransport := &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(vtt)),
+				}
+				resp.Header.Set(logIDHeader, "test_log_id")
+				return resp, nil
+			},
+		}
+
+		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		speech := newSpeech(core)
+
+		resp, err := speech.TranscriptionWithOptions(context.Background(), strings.NewReader("mp3 bytes"),
+			WithTranscriptionResponseFormat(TranscriptionResponseFormatVTT),
+		)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Data.Segments, 2)
+		assert.Equal(t, "hello", resp.Data.Segments[0].Text)
+		assert.Equal(t, 1.5, resp.Data.Segments[0].End)
+		assert.Equal(t, "world", resp.Data.Segments[1].Text)
+		assert.Equal(t, float64(3603), resp.Data.Segments[1].End)
+	})
+
+	t.Run("legacy Transcription signature still compiles and works", func(t *testing.T) {
+		mockTransport := &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"text":"this_test"}}`)),
+				}
+				resp.Header.Set(logIDHeader, "test_log_id")
+				return resp, nil
+			},
+		}
+
+		core := newCore(&http.Client{Transport: mockTransport}, ComBaseURL)
+		speech := newSpeech(core)
+
+		resp, err := speech.Transcription(context.Background(), strings.NewReader("testmp3"), "en")
+		require.NoError(t, err)
+		assert.Equal(t, "this_test", resp.Data.Text)
+	})
+}
@@ -0,0 +1,250 @@
+package coze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// audioTranscriptionStreamPath is the WebSocket endpoint for streaming ASR.
+const audioTranscriptionStreamPath = "/v1/audio/transcriptions"
+
+// TranscriptionAudioEncoding identifies the raw audio encoding pushed to a
+// TranscriptionStream.
+type TranscriptionAudioEncoding string
+
+const (
+	TranscriptionAudioEncodingLinear16 TranscriptionAudioEncoding = "linear16"
+	TranscriptionAudioEncodingOpus     TranscriptionAudioEncoding = "opus"
+	TranscriptionAudioEncodingMulaw    TranscriptionAudioEncoding = "mulaw"
+)
+
+// TranscriptionRetryPolicy controls automatic reconnection of a
+// TranscriptionStream when the underlying WebSocket connection drops.
+type TranscriptionRetryPolicy struct {
+	// MaxRetries is the number of reconnect attempts before Recv gives up.
+	// Zero disables automatic reconnection.
+	MaxRetries int
+
+	// Backoff is the delay between reconnect attempts.
+	Backoff time.Duration
+}
+
+// TranscriptionStreamConfig configures a streaming ASR session.
+type TranscriptionStreamConfig struct {
+	SampleRate     int                        `json:"sample_rate"`
+	Encoding       TranscriptionAudioEncoding `json:"encoding"`
+	Language       string                     `json:"language,omitempty"`
+	EnablePartials bool                       `json:"enable_partials"`
+	VADSilenceMS   int                        `json:"vad_silence_ms,omitempty"`
+	Hotwords       []string                   `json:"hotwords,omitempty"`
+
+	// RetryPolicy controls reconnect behavior; the zero value disables retries.
+	RetryPolicy TranscriptionRetryPolicy `json:"-"`
+
+	// Path overrides the default WebSocket path, mainly for testing.
+	Path string `json:"-"`
+}
+
+// Word is a single word-level timestamp within a FinalTranscript event.
+type Word struct {
+	Text       string  `json:"text"`
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Confidence float64 `json:"confidence"`
+}
+
+// TranscriptionEventType identifies the kind of event emitted by
+// TranscriptionStream.Recv.
+type TranscriptionEventType string
+
+const (
+	TranscriptionEventSpeechStarted     TranscriptionEventType = "speech_started"
+	TranscriptionEventPartialTranscript TranscriptionEventType = "partial_transcript"
+	TranscriptionEventFinalTranscript   TranscriptionEventType = "final_transcript"
+	TranscriptionEventUtteranceEnd      TranscriptionEventType = "utterance_end"
+)
+
+// TranscriptionEvent is the typed union returned by TranscriptionStream.Recv.
+type TranscriptionEvent struct {
+	Type TranscriptionEventType
+
+	// Text holds the transcript for PartialTranscript and FinalTranscript events.
+	Text string
+
+	// Words holds word-level timestamps, populated on FinalTranscript events only.
+	Words []Word
+}
+
+// TranscriptionStream is a bidirectional streaming ASR session: callers push
+// raw audio via Send and read partial/final transcripts via Recv.
+type TranscriptionStream struct {
+	core   *core
+	config TranscriptionStreamConfig
+
+	mu    sync.Mutex
+	conn  *websocket.Conn
+	logID string
+
+	// receivedSinceDial is true once at least one event has been delivered
+	// on the current connection. It gates reconnection: replaying the
+	// config after a drop restarts the utterance server-side, so once any
+	// transcript has already been delivered there is no resume token to
+	// avoid duplicating or losing it, and Recv surfaces the raw error
+	// instead of silently reconnecting.
+	receivedSinceDial bool
+}
+
+// TranscriptionStream opens a streaming ASR session over WebSocket. Callers
+// push raw audio frames with Send, signal end of input with CloseSend, and
+// consume SpeechStarted/PartialTranscript/FinalTranscript/UtteranceEnd events
+// with Recv.
+func (r *speech) TranscriptionStream(ctx context.Context, config TranscriptionStreamConfig) (*TranscriptionStream, error) {
+	stream := &TranscriptionStream{core: r.core, config: config}
+	if err := stream.dial(ctx); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (s *TranscriptionStream) dial(ctx context.Context) error {
+	path := s.config.Path
+	if path == "" {
+		path = audioTranscriptionStreamPath
+	}
+
+	wsURL, err := toWebSocketURL(s.core.baseURL, path)
+	if err != nil {
+		return err
+	}
+
+	header, err := s.core.authHeader(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn, httpResp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	if err != nil {
+		return fmt.Errorf("coze: dial transcription stream: %w", err)
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.receivedSinceDial = false
+	if httpResp != nil {
+		s.logID = httpResp.Header.Get(logIDHeader)
+	}
+	s.mu.Unlock()
+
+	return s.sendJSON(map[string]any{
+		"event_type":      "config",
+		"sample_rate":     s.config.SampleRate,
+		"encoding":        s.config.Encoding,
+		"language":        s.config.Language,
+		"enable_partials": s.config.EnablePartials,
+		"vad_silence_ms":  s.config.VADSilenceMS,
+		"hotwords":        s.config.Hotwords,
+	})
+}
+
+// Send pushes a chunk of raw audio (PCM/opus/mulaw, per the configured
+// encoding) to the server.
+func (s *TranscriptionStream) Send(audio []byte) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	return conn.WriteMessage(websocket.BinaryMessage, audio)
+}
+
+// CloseSend signals that no further audio will be sent, without closing the
+// connection for reading; the server keeps streaming final events until it
+// closes the socket.
+func (s *TranscriptionStream) CloseSend() error {
+	return s.sendJSON(map[string]any{"event_type": "close_send"})
+}
+
+// Recv blocks until the next transcription event is available. A clean
+// server-initiated close (the server finished the utterance and hung up
+// normally) is reported as io.EOF. A dropped connection is only retried,
+// per the configured TranscriptionRetryPolicy, if no event has been
+// delivered yet on the current connection; once the server has started
+// streaming transcripts there is no resume token, so reconnecting would
+// either duplicate or silently lose data, and Recv instead returns the raw
+// error for the caller to handle.
+func (s *TranscriptionStream) Recv() (*TranscriptionEvent, error) {
+	attempt := 0
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		receivedSinceDial := s.receivedSinceDial
+		s.mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err == nil {
+			event, parseErr := parseTranscriptionEvent(data)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			s.mu.Lock()
+			s.receivedSinceDial = true
+			s.mu.Unlock()
+			return event, nil
+		}
+
+		var closeErr *websocket.CloseError
+		if errors.As(err, &closeErr) && (closeErr.Code == websocket.CloseNormalClosure || closeErr.Code == websocket.CloseGoingAway) {
+			return nil, io.EOF
+		}
+
+		if receivedSinceDial || attempt >= s.config.RetryPolicy.MaxRetries {
+			return nil, err
+		}
+		attempt++
+		time.Sleep(s.config.RetryPolicy.Backoff)
+		if dialErr := s.dial(context.Background()); dialErr != nil {
+			return nil, dialErr
+		}
+	}
+}
+
+// LogID returns the log ID of the WebSocket upgrade response, matching
+// HTTPResponse.LogID() for the REST APIs.
+func (s *TranscriptionStream) LogID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logID
+}
+
+// Close closes the underlying WebSocket connection.
+func (s *TranscriptionStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+func (s *TranscriptionStream) sendJSON(v map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WriteJSON(v)
+}
+
+func parseTranscriptionEvent(data []byte) (*TranscriptionEvent, error) {
+	var wire struct {
+		EventType TranscriptionEventType `json:"event_type"`
+		Text      string                 `json:"text"`
+		Words     []Word                 `json:"words"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, fmt.Errorf("coze: decode transcription event: %w", err)
+	}
+	return &TranscriptionEvent{Type: wire.EventType, Text: wire.Text, Words: wire.Words}, nil
+}
@@ -0,0 +1,181 @@
+package coze
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockTranscriptionStreamServer writes each message of script, in order,
+// once it receives the client's initial config message, using net/http/httptest
+// rather than net.Pipe directly since the gorilla/websocket handshake needs
+// a real HTTP server to upgrade.
+func newMockTranscriptionStreamServer(t *testing.T, script []string) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/v1/audio/transcriptions", req.URL.Path)
+
+		conn, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// consume the config message
+		_, _, err = conn.ReadMessage()
+		require.NoError(t, err)
+
+		for _, msg := range script {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(msg)))
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAudioTranscriptionStream(t *testing.T) {
+	cases := []struct {
+		name     string
+		partials bool
+		script   []string
+		want     []TranscriptionEventType
+	}{
+		{
+			name:     "partial then final",
+			partials: true,
+			script: []string{
+				`{"event_type":"speech_started"}`,
+				`{"event_type":"partial_transcript","text":"hel"}`,
+				`{"event_type":"final_transcript","text":"hello","words":[{"text":"hello","start":0,"end":0.4,"confidence":0.9}]}`,
+				`{"event_type":"utterance_end"}`,
+			},
+			want: []TranscriptionEventType{
+				TranscriptionEventSpeechStarted,
+				TranscriptionEventPartialTranscript,
+				TranscriptionEventFinalTranscript,
+				TranscriptionEventUtteranceEnd,
+			},
+		},
+		{
+			name:   "final only when partials disabled",
+			script: []string{`{"event_type":"final_transcript","text":"hi"}`},
+			want:   []TranscriptionEventType{TranscriptionEventFinalTranscript},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newMockTranscriptionStreamServer(t, tc.script)
+
+			core := newCore(http.DefaultClient, srv.URL)
+			speech := newSpeech(core)
+
+			stream, err := speech.TranscriptionStream(context.Background(), TranscriptionStreamConfig{
+				SampleRate:     16000,
+				Encoding:       TranscriptionAudioEncodingLinear16,
+				EnablePartials: tc.partials,
+				RetryPolicy:    TranscriptionRetryPolicy{MaxRetries: 1, Backoff: 10 * time.Millisecond},
+			})
+			require.NoError(t, err)
+			defer stream.Close()
+
+			var got []TranscriptionEventType
+			for range tc.want {
+				ev, err := stream.Recv()
+				require.NoError(t, err)
+				got = append(got, ev.Type)
+			}
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestAudioTranscriptionStreamReconnectsBeforeFirstEvent verifies that a
+// dropped connection is retried when no event has been delivered yet, since
+// replaying the config at that point can't duplicate or lose any transcript.
+func TestAudioTranscriptionStreamReconnectsBeforeFirstEvent(t *testing.T) {
+	var dials int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _, err = conn.ReadMessage() // consume the config message
+		require.NoError(t, err)
+
+		if atomic.AddInt32(&dials, 1) == 1 {
+			return // first connection: drop abruptly, before sending anything
+		}
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"speech_started"}`)))
+	}))
+	t.Cleanup(srv.Close)
+
+	core := newCore(http.DefaultClient, srv.URL)
+	speech := newSpeech(core)
+
+	stream, err := speech.TranscriptionStream(context.Background(), TranscriptionStreamConfig{
+		SampleRate:  16000,
+		Encoding:    TranscriptionAudioEncodingLinear16,
+		RetryPolicy: TranscriptionRetryPolicy{MaxRetries: 1, Backoff: 10 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, TranscriptionEventSpeechStarted, ev.Type)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&dials))
+}
+
+// TestAudioTranscriptionStreamNoReplayAfterEvent verifies that once an event
+// has been delivered, a dropped connection is surfaced as an error instead
+// of being silently retried, since there is no resume token to avoid
+// duplicating or losing the transcript already in flight.
+func TestAudioTranscriptionStreamNoReplayAfterEvent(t *testing.T) {
+	var dials int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&dials, 1)
+		conn, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _, err = conn.ReadMessage() // consume the config message
+		require.NoError(t, err)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"partial_transcript","text":"hel"}`)))
+		// then drop the connection abruptly, without a close frame
+	}))
+	t.Cleanup(srv.Close)
+
+	core := newCore(http.DefaultClient, srv.URL)
+	speech := newSpeech(core)
+
+	stream, err := speech.TranscriptionStream(context.Background(), TranscriptionStreamConfig{
+		SampleRate:  16000,
+		Encoding:    TranscriptionAudioEncodingLinear16,
+		RetryPolicy: TranscriptionRetryPolicy{MaxRetries: 5, Backoff: 10 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, TranscriptionEventPartialTranscript, ev.Type)
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&dials))
+}
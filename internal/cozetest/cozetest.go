@@ -0,0 +1,282 @@
+// Package cozetest provides a record/replay HTTP test harness for the coze
+// client tests, inspired by the test-proxy approach used by other SDKs: a
+// test runs once against the real API in Record mode, capturing each
+// request/response pair to testdata/<testname>.yaml, then runs offline in
+// Replay mode against that recording in CI. Request headers that carry
+// credentials (Authorization, X-Api-Key, Cookie) are redacted before a
+// cassette is written, and the response log ID is replaced with a stable
+// placeholder so recordings are deterministic across re-recordings.
+package cozetest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode selects how NewRecorder behaves.
+type Mode int
+
+const (
+	// Replay serves responses from the cassette on disk and fails the test
+	// if a request doesn't match a recorded interaction.
+	Replay Mode = iota
+
+	// Record proxies requests to the real API and writes the
+	// request/response pairs to the cassette, overwriting any existing one.
+	Record
+
+	// Passthrough proxies requests to the real API without recording
+	// anything; useful for one-off manual runs.
+	Passthrough
+)
+
+// interaction is one recorded request/response pair.
+type interaction struct {
+	Method        string            `yaml:"method"`
+	Path          string            `yaml:"path"`
+	RequestHeader map[string]string `yaml:"request_header,omitempty"`
+	RequestBody   string            `yaml:"request_body,omitempty"`
+	Status        int               `yaml:"status"`
+	Header        map[string]string `yaml:"header,omitempty"`
+	Body          string            `yaml:"body"`
+	BodyBase64    bool              `yaml:"body_base64,omitempty"`
+}
+
+type cassette struct {
+	Interactions []interaction `yaml:"interactions"`
+}
+
+// stableLogID replaces the real log ID in recordings so cassettes are
+// deterministic across re-recordings.
+const stableLogID = "cozetest_log_id"
+
+// redactedPlaceholder replaces the value of any header in redactedHeaders so
+// tokens never touch disk, while keeping the header's presence visible in
+// the cassette for debugging.
+const redactedPlaceholder = "REDACTED"
+
+// redactedHeaders lists request header names whose values must never be
+// written to a cassette.
+var redactedHeaders = []string{"Authorization", "X-Api-Key", "Cookie"}
+
+var timestampPattern = regexp.MustCompile(`"(created_at|updated_at|timestamp)":\s*\d+`)
+
+// NewRecorder returns an *http.Client whose RoundTripper either replays
+// req/resp pairs from testdata/<TestName>.yaml (mode == Replay), or proxies
+// to base while recording them there (mode == Record), or proxies without
+// recording (mode == Passthrough).
+func NewRecorder(t *testing.T, mode Mode, base http.RoundTripper) *http.Client {
+	t.Helper()
+
+	path := cassettePath(t)
+
+	switch mode {
+	case Replay:
+		c, err := loadCassette(path)
+		if err != nil {
+			t.Fatalf("cozetest: load cassette %s: %v", path, err)
+		}
+		return &http.Client{Transport: &replayTransport{t: t, interactions: c.Interactions}}
+	case Record:
+		rt := &recordTransport{base: base}
+		t.Cleanup(func() {
+			if err := saveCassette(path, &cassette{Interactions: rt.interactions}); err != nil {
+				t.Fatalf("cozetest: save cassette %s: %v", path, err)
+			}
+		})
+		return &http.Client{Transport: rt}
+	default:
+		return &http.Client{Transport: base}
+	}
+}
+
+// cassettePath is keyed by the top-level test name only, so a test can
+// record in one subtest (e.g. "record") and replay in another (e.g.
+// "replay") against the same cassette file.
+func cassettePath(t *testing.T) string {
+	name := strings.SplitN(t.Name(), "/", 2)[0]
+	name = strings.NewReplacer("/", "_", " ", "_").Replace(name)
+	return filepath.Join("testdata", name+".yaml")
+}
+
+// Server is an ad-hoc stub HTTP server for tests that don't need a
+// cassette, returned by NewServer.
+type Server struct {
+	mux *http.ServeMux
+	srv *httptest.Server
+}
+
+// RegisterHandler registers h for path on the stub server.
+func (s *Server) RegisterHandler(path string, h http.HandlerFunc) {
+	s.mux.HandleFunc(path, h)
+}
+
+// URL returns the stub server's base URL.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// NewServer starts an ad-hoc stub HTTP server and returns a client bound to
+// it, the server so handlers can be registered, and a teardown func. Callers
+// register handlers with RegisterHandler before issuing requests.
+func NewServer(t *testing.T) (*http.Client, *Server, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	httpSrv := httptest.NewServer(mux)
+	s := &Server{mux: mux, srv: httpSrv}
+
+	return httpSrv.Client(), s, httpSrv.Close
+}
+
+type recordTransport struct {
+	base         http.RoundTripper
+	interactions []interaction
+}
+
+func (rt *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	rt.interactions = append(rt.interactions, buildInteraction(req, reqBody, resp, respBody))
+	return resp, nil
+}
+
+func buildInteraction(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) interaction {
+	reqHeader := map[string]string{}
+	for k := range req.Header {
+		reqHeader[k] = req.Header.Get(k)
+	}
+	redactHeaders(reqHeader)
+
+	header := map[string]string{}
+	for k := range resp.Header {
+		if k == "Log-Id" || k == "X-Tt-Logid" {
+			header[k] = stableLogID
+			continue
+		}
+		header[k] = resp.Header.Get(k)
+	}
+
+	isBinary := strings.HasPrefix(resp.Header.Get("Content-Type"), "audio/")
+	body := string(respBody)
+	if isBinary {
+		body = base64.StdEncoding.EncodeToString(respBody)
+	} else {
+		body = string(timestampPattern.ReplaceAll([]byte(body), []byte(`"$1": 0`)))
+	}
+
+	return interaction{
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		RequestHeader: reqHeader,
+		RequestBody:   string(reqBody),
+		Status:        resp.StatusCode,
+		Header:        header,
+		Body:          body,
+		BodyBase64:    isBinary,
+	}
+}
+
+// redactHeaders overwrites the values of redactedHeaders in place so
+// credentials never reach the cassette on disk.
+func redactHeaders(header map[string]string) {
+	for _, name := range redactedHeaders {
+		for k := range header {
+			if strings.EqualFold(k, name) {
+				header[k] = redactedPlaceholder
+			}
+		}
+	}
+}
+
+type replayTransport struct {
+	t            *testing.T
+	interactions []interaction
+	next         int
+}
+
+func (rt *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.next >= len(rt.interactions) {
+		rt.t.Fatalf("cozetest: no recorded interaction left for %s %s", req.Method, req.URL.Path)
+	}
+	i := rt.interactions[rt.next]
+	rt.next++
+
+	if i.Method != req.Method || i.Path != req.URL.Path {
+		rt.t.Fatalf("cozetest: recorded interaction %d is %s %s, got %s %s", rt.next-1, i.Method, i.Path, req.Method, req.URL.Path)
+	}
+
+	body := []byte(i.Body)
+	if i.BodyBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(i.Body)
+		if err != nil {
+			return nil, fmt.Errorf("cozetest: decode base64 body: %w", err)
+		}
+		body = decoded
+	}
+
+	header := http.Header{}
+	for k, v := range i.Header {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		StatusCode: i.Status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func loadCassette(path string) (*cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func saveCassette(path string, c *cassette) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
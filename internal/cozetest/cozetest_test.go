@@ -0,0 +1,87 @@
+package cozetest
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer(t *testing.T) {
+	client, srv, teardown := NewServer(t)
+	defer teardown()
+
+	srv.RegisterHandler("/v1/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	resp, err := client.Get(srv.URL() + "/v1/ping")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(body))
+}
+
+func TestRecorderRecordThenReplay(t *testing.T) {
+	_, srv, teardown := NewServer(t)
+	defer teardown()
+
+	var serverHits int
+	srv.RegisterHandler("/v1/ping", func(w http.ResponseWriter, r *http.Request) {
+		serverHits++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Log-Id", "real_log_id")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	// Record phase: hits the (stub) server and writes testdata/<test>.yaml
+	// once the subtest's Cleanup runs.
+	t.Run("record", func(t *testing.T) {
+		recorded := NewRecorder(t, Record, http.DefaultTransport)
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL()+"/v1/ping", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret-token")
+
+		resp, err := recorded.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ok":true}`, string(body))
+	})
+
+	require.Equal(t, 1, serverHits)
+
+	// Replay phase: served entirely from the cassette written above,
+	// without touching the server again.
+	t.Run("replay", func(t *testing.T) {
+		replayed := NewRecorder(t, Replay, nil)
+
+		resp, err := replayed.Get(srv.URL() + "/v1/ping")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, "cozetest_log_id", resp.Header.Get("Log-Id"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ok":true}`, string(body))
+	})
+
+	assert.Equal(t, 1, serverHits, "replay must not hit the server again")
+
+	data, err := os.ReadFile(filepath.Join("testdata", "TestRecorderRecordThenReplay.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.Contains(t, string(data), redactedPlaceholder)
+}